@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strconv"
@@ -24,6 +26,10 @@ import (
 const (
 	caFile = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 	nurse  = "I'm ready to help you!"
+
+	// shutdownTimeout bounds both the http server shutdown and waiting for an
+	// in-progress check run
+	shutdownTimeout = 10 * time.Second
 )
 
 //nolint:funlen
@@ -49,7 +55,7 @@ func main() {
 		case s := <-sig:
 			log.Printf("shutting down, received signal %s", s)
 
-			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 			defer shutdownCancel()
 
 			if err := server.Shutdown(shutdownCtx); err != nil {
@@ -97,6 +103,11 @@ func main() {
 	chk.NeighbourFilter = os.Getenv("KUBENURSE_NEIGHBOUR_FILTER")
 	chk.UseTLS = useTLS
 
+	chk.ServiceLBURL = os.Getenv("KUBENURSE_SERVICE_LB_URL")
+	if err := validateServiceLBURL(chk.ServiceLBURL); err != nil {
+		log.Fatalln(err)
+	}
+
 	// setup http routes
 	mux.HandleFunc("/alive", aliveHandler(chk))
 	mux.HandleFunc("/alwayshappy", func(http.ResponseWriter, *http.Request) {})
@@ -105,11 +116,8 @@ func main() {
 
 	fmt.Println(nurse) // most important line of this project
 
-	// Start listener and checker
-	go func() {
-		chk.RunScheduled(5 * time.Second)
-		log.Fatalln("checker exited")
-	}()
+	// Start listener and checker, the checker loop ends with chk.Stop below
+	go chk.RunScheduled(5 * time.Second)
 
 	go func() {
 		if err := server.ListenAndServe(); err != nil {
@@ -130,6 +138,21 @@ func main() {
 	}
 
 	<-ctx.Done()
+
+	// Wait for an in-progress check run before exiting, a run cannot be
+	// cancelled and may take several client timeouts to finish
+	stopped := make(chan struct{})
+
+	go func() {
+		chk.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		log.Printf("checker did not stop within %s, exiting anyway", shutdownTimeout)
+	}
 }
 
 func aliveHandler(chk *checker.Checker) func(w http.ResponseWriter, r *http.Request) {
@@ -146,6 +169,7 @@ func aliveHandler(chk *checker.Checker) func(w http.ResponseWriter, r *http.Requ
 			APIServerDNS    string `json:"api_server_dns"`
 			MeIngress       string `json:"me_ingress"`
 			MeService       string `json:"me_service"`
+			ServiceLB       string `json:"service_lb,omitempty"`
 
 			// kubediscovery
 			NeighbourhoodState string                    `json:"neighbourhood_state"`
@@ -164,6 +188,7 @@ func aliveHandler(chk *checker.Checker) func(w http.ResponseWriter, r *http.Requ
 			APIServerDirect:    res.APIServerDirect,
 			MeIngress:          res.MeIngress,
 			MeService:          res.MeService,
+			ServiceLB:          res.ServiceLB,
 			Headers:            r.Header,
 			UserAgent:          r.UserAgent(),
 			RequestURI:         r.RequestURI,
@@ -228,3 +253,22 @@ func GenerateRoundTripper() (http.RoundTripper, error) {
 
 	return transport, nil
 }
+
+// validateServiceLBURL makes sure the LoadBalancer URL, if set, uses an IP
+// address so that the service_lb check does not depend on DNS resolution.
+func validateServiceLBURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("could not parse KUBENURSE_SERVICE_LB_URL %s: %s", rawURL, err)
+	}
+
+	if net.ParseIP(u.Hostname()) == nil {
+		return fmt.Errorf("KUBENURSE_SERVICE_LB_URL %s must use an IP address, not a hostname", rawURL)
+	}
+
+	return nil
+}
@@ -57,6 +57,12 @@ func (c *Checker) Run() (Result, bool) {
 	res.MeService, err = measure(c.MeService, "me_service")
 	haserr = haserr || (err != nil)
 
+	// The LoadBalancer check is optional
+	if c.ServiceLBURL != "" {
+		res.ServiceLB, err = measure(c.ServiceLB, "service_lb")
+		haserr = haserr || (err != nil)
+	}
+
 	res.Neighbourhood, err = c.discovery.GetNeighbours(context.TODO(), c.KubenurseNamespace, c.NeighbourFilter)
 	haserr = haserr || (err != nil)
 
@@ -77,10 +83,70 @@ func (c *Checker) Run() (Result, bool) {
 }
 
 // RunScheduled runs the check run in the specified interval which can be used
-// to keep the metrics up-to-date.
+// to keep the metrics up-to-date. It returns after Stop has been called, or
+// immediately if Stop was called before or the loop is already running.
 func (c *Checker) RunScheduled(d time.Duration) {
-	for range time.Tick(d) {
-		c.Run()
+	c.scheduleMu.Lock()
+	c.initSchedule()
+
+	if c.started || c.stopped {
+		c.scheduleMu.Unlock()
+		return
+	}
+
+	c.started = true
+	stopCh, done := c.stopCh, c.done
+	c.scheduleMu.Unlock()
+
+	defer close(done)
+
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			// Stop might have been requested while the tick was pending
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+
+			c.Run()
+		}
+	}
+}
+
+// Stop signals RunScheduled to exit and waits until an in-progress check run
+// has finished. A run cannot be cancelled, so Stop can take as long as a full
+// check run. It is safe to call Stop more than once.
+func (c *Checker) Stop() {
+	c.scheduleMu.Lock()
+	c.initSchedule()
+
+	if !c.stopped {
+		c.stopped = true
+		close(c.stopCh)
+	}
+
+	started, done := c.started, c.done
+	c.scheduleMu.Unlock()
+
+	if started {
+		<-done
+	}
+}
+
+// initSchedule creates the channels of the RunScheduled loop on first use, so
+// that RunScheduled and Stop also work on a Checker not created by New. The
+// caller must hold scheduleMu.
+func (c *Checker) initSchedule() {
+	if c.stopCh == nil {
+		c.stopCh = make(chan struct{})
+		c.done = make(chan struct{})
 	}
 }
 
@@ -106,6 +172,11 @@ func (c *Checker) MeService() (string, error) {
 	return c.doRequest(c.KubenurseServiceURL + "/alwayshappy")
 }
 
+// ServiceLB checks if the kubenurse is reachable at the /alwayshappy endpoint through a LoadBalancer service
+func (c *Checker) ServiceLB() (string, error) {
+	return c.doRequest(c.ServiceLBURL + "/alwayshappy")
+}
+
 // checkNeighbours checks the /alwayshappy endpoint from every discovered kubenurse neighbour. Neighbour pods on nodes
 // which are not schedulable are excluded from this check to avoid possible false errors.
 func (c *Checker) checkNeighbours(nh []kubediscovery.Neighbour) {
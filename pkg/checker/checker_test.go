@@ -0,0 +1,171 @@
+package checker
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/postfinance/kubenurse/pkg/kubediscovery"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunScheduled(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Every run requests the me_service check exactly once, so the service
+	// handler counts the runs. The third request blocks until it is released
+	// to keep a run in progress while Stop is called.
+	const blockingRun = 3
+
+	var (
+		runs, active int32
+		releaseOnce  sync.Once
+	)
+
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+
+		if atomic.AddInt32(&runs, 1) == blockingRun {
+			close(inFlight)
+			<-release
+		}
+	}))
+	defer srv.Close()
+	defer unblock()
+
+	chk := newTestChecker(ctx, t)
+	chk.KubenurseServiceURL = srv.URL
+
+	interval := 10 * time.Millisecond
+	done := make(chan struct{})
+
+	go func() {
+		chk.RunScheduled(interval)
+		close(done)
+	}()
+
+	select {
+	case <-inFlight:
+	case <-time.After(time.Second):
+		r.FailNow("blocking run was not started")
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		chk.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		r.FailNow("Stop returned while a run was in progress")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unblock()
+
+	select {
+	case <-stopped:
+	case <-time.After(500 * time.Millisecond):
+		r.FailNow("Stop did not return after the run finished")
+	}
+
+	select {
+	case <-done:
+	default:
+		r.FailNow("RunScheduled did not exit before Stop returned")
+	}
+
+	r.Equal(int32(0), atomic.LoadInt32(&active), "no run in progress at exit")
+
+	time.Sleep(3 * interval)
+	r.Equal(int32(blockingRun), atomic.LoadInt32(&runs), "no runs after Stop")
+
+	// A second Stop must not block or panic
+	chk.Stop()
+}
+
+// newTestChecker returns a Checker with a fake k8s client and a dummy
+// ServiceAccount token, so the checks do not depend on running in a cluster.
+func newTestChecker(ctx context.Context, t *testing.T) *Checker {
+	t.Helper()
+
+	r := require.New(t)
+
+	discovery, err := kubediscovery.NewWithClientset(ctx, fake.NewSimpleClientset(), true)
+	r.NoError(err)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	r.NoError(ioutil.WriteFile(tokenFile, []byte("token"), 0600))
+
+	return &Checker{
+		discovery:  discovery,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		tokenFile:  tokenFile,
+	}
+}
+
+func TestServiceLB(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		paths = append(paths, req.URL.Path)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	requested := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), paths...)
+	}
+
+	t.Run("url set", func(t *testing.T) {
+		r := require.New(t)
+
+		chk := newTestChecker(ctx, t)
+		chk.ServiceLBURL = srv.URL
+
+		res, _ := chk.Run()
+		r.Equal("ok", res.ServiceLB)
+		r.Equal([]string{"/alwayshappy"}, requested())
+	})
+
+	mu.Lock()
+	paths = nil
+	mu.Unlock()
+
+	t.Run("url empty", func(t *testing.T) {
+		r := require.New(t)
+
+		chk := newTestChecker(ctx, t)
+
+		res, _ := chk.Run()
+		r.Empty(res.ServiceLB)
+		r.Empty(requested())
+	})
+}
@@ -10,11 +10,16 @@ import (
 
 const (
 	//nolint:gosec
-	tokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	defaultTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 )
 
 // doRequest does an http request only to get the http status code
 func (c *Checker) doRequest(url string) (string, error) {
+	tokenFile := c.tokenFile
+	if tokenFile == "" {
+		tokenFile = defaultTokenFile
+	}
+
 	// Read Bearer Token file from ServiceAccount
 	token, err := ioutil.ReadFile(tokenFile)
 	if err != nil {
@@ -2,6 +2,7 @@ package checker
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/postfinance/kubenurse/pkg/kubediscovery"
@@ -13,6 +14,9 @@ type Checker struct {
 	KubenurseIngressURL string
 	KubenurseServiceURL string
 
+	// Optional LoadBalancer service config
+	ServiceLBURL string
+
 	// Kubernetes API
 	KubernetesServiceHost string
 	KubernetesServicePort string
@@ -30,11 +34,22 @@ type Checker struct {
 	// Http Client for https requests
 	httpClient *http.Client
 
+	// tokenFile overrides the ServiceAccount token path if set
+	tokenFile string
+
 	// cachedResult represents a cached check result
 	cachedResult *CachedResult
 
 	// cacheTTL defines the TTL of how long a cached result is valid
 	cacheTTL time.Duration
+
+	// scheduleMu guards the state of the RunScheduled loop. stopCh is closed
+	// by Stop to signal the loop to exit, done is closed when it has returned.
+	scheduleMu sync.Mutex
+	started    bool
+	stopped    bool
+	stopCh     chan struct{}
+	done       chan struct{}
 }
 
 // Result contains the result of a performed check run
@@ -43,6 +58,7 @@ type Result struct {
 	APIServerDNS       string                    `json:"api_server_dns"`
 	MeIngress          string                    `json:"me_ingress"`
 	MeService          string                    `json:"me_service"`
+	ServiceLB          string                    `json:"service_lb,omitempty"`
 	NeighbourhoodState string                    `json:"neighbourhood_state"`
 	Neighbourhood      []kubediscovery.Neighbour `json:"neighbourhood"`
 }
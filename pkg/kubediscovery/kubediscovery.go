@@ -54,7 +54,16 @@ func New(ctx context.Context, allowUnschedulable bool) (*Client, error) {
 		return nil, fmt.Errorf("creating clientset: %w", err)
 	}
 
-	var nc *nodeCache
+	return NewWithClientset(ctx, cliset, allowUnschedulable)
+}
+
+// NewWithClientset creates a new kubediscovery client using the given clientset
+// instead of the in-cluster configuration. See New for the meaning of the parameters.
+func NewWithClientset(ctx context.Context, cliset kubernetes.Interface, allowUnschedulable bool) (*Client, error) {
+	var (
+		nc  *nodeCache
+		err error
+	)
 
 	// Watch nodes only if we do not consider kubenurses on unschedulable nodes
 	if !allowUnschedulable {